@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// logEntryPointNames is the set of method/function names that sit between
+// fireEntry and the user's real call site: the Log/Logf/Debug/.../Fatalf
+// methods on *LogExtended and *Entry, and mlog's package-level wrappers of
+// the same names, which add one more frame on top of those. A plain package
+// prefix can't identify these, since user code calling logger methods from
+// within the logger package itself - this package's own tests, say - would
+// be misidentified as internal too.
+var logEntryPointNames = []string{"Log", "Logf", "Debug", "Debugf", "Info", "Infof", "Warn", "Warnf", "Error", "Errorf", "Fatal", "Fatalf"}
+
+var internalFuncs = buildInternalFuncs()
+
+func buildInternalFuncs() map[string]bool {
+	set := map[string]bool{
+		"github.com/ariocp/mlog/logger.(*LogExtended).fireEntry": true,
+		"github.com/ariocp/mlog/logger.resolveStack":             true,
+	}
+	for _, name := range logEntryPointNames {
+		set["github.com/ariocp/mlog/logger.(*LogExtended)."+name] = true
+		set["github.com/ariocp/mlog/logger.(*Entry)."+name] = true
+		set["github.com/ariocp/mlog/mlog."+name] = true
+	}
+	return set
+}
+
+// isInternalFunc reports whether function is one of the logger's own frames
+// (resolveStack, fireEntry, or one of the entry points calling it) between
+// captureCaller/captureStack and the user's call site: both walk
+// past every such frame, so the same capture logic resolves the true call
+// site whether the user called a *LogExtended/*Entry method directly or
+// through the mlog.* wrappers - a fixed skip count can't be correct for
+// both, since the wrappers add a frame the direct call style doesn't have.
+func isInternalFunc(function string) bool {
+	return internalFuncs[function]
+}
+
+// StackTracer is implemented by errors that carry their own stack trace,
+// following the convention popularized by github.com/pkg/errors. WithError
+// prefers this stack over one captured at log time.
+type StackTracer interface {
+	StackTrace() string
+}
+
+// SetReportCaller controls whether fired entries are annotated with the
+// file:line of their call site (as a "caller" attribute), and, for
+// Error/Fatal entries, a captured stack trace (as a "stack" attribute). It
+// is off by default since runtime.Caller/runtime.Callers are not free.
+func (l *LogExtended) SetReportCaller(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reportCaller = enabled
+}
+
+// callerFrames returns the frames starting at whoever called captureCaller
+// or captureStack (fireEntry or resolveStack), skipping runtime.Callers,
+// callerFrames itself and captureCaller/captureStack.
+func callerFrames() *runtime.Frames {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	return runtime.CallersFrames(pcs[:n])
+}
+
+func captureCaller() (string, bool) {
+	frames := callerFrames()
+	for {
+		frame, more := frames.Next()
+		if !isInternalFunc(frame.Function) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line), true
+		}
+		if !more {
+			return "", false
+		}
+	}
+}
+
+func captureStack() string {
+	frames := callerFrames()
+
+	var buf strings.Builder
+	skippingInternal := true
+	for {
+		frame, more := frames.Next()
+		if skippingInternal {
+			if isInternalFunc(frame.Function) {
+				if !more {
+					break
+				}
+				continue
+			}
+			skippingInternal = false
+		}
+		fmt.Fprintf(&buf, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// resolveStack prefers errVal's own StackTrace, if it implements
+// StackTracer, over a freshly captured one.
+func resolveStack(errVal interface{}) string {
+	if tracer, ok := errVal.(StackTracer); ok {
+		return tracer.StackTrace()
+	}
+	return captureStack()
+}
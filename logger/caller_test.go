@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type captureHook struct {
+	mu      sync.Mutex
+	levels  []LogLevel
+	entries []*Entry
+}
+
+func (h *captureHook) Levels() []LogLevel {
+	if h.levels == nil {
+		return []LogLevel{LogLevelInfo}
+	}
+	return h.levels
+}
+
+func (h *captureHook) Fire(entry *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func TestCaptureCallerDirectCall(t *testing.T) {
+	l := New()
+	defer l.Close()
+	l.SetReportCaller(true)
+
+	hook := &captureHook{}
+	l.AddHook(hook)
+
+	l.Info("hi")
+	wantLine := 37 // the l.Info("hi") call above
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(hook.entries))
+	}
+
+	caller, _ := hook.entries[0].Data["caller"].(string)
+	want := fmt.Sprintf("caller_test.go:%d", wantLine)
+	if caller != want {
+		t.Errorf("caller = %q, want %q", caller, want)
+	}
+}
+
+func TestFireEntryOmitsStackWhenReportCallerDisabled(t *testing.T) {
+	l := New()
+	defer l.Close()
+
+	hook := &captureHook{levels: []LogLevel{LogLevelError}}
+	l.AddHook(hook)
+
+	l.Error("boom")
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(hook.entries))
+	}
+	if _, ok := hook.entries[0].Data["stack"]; ok {
+		t.Error("fireEntry attached a stack trace with SetReportCaller off")
+	}
+}
+
+func TestFireEntryAttachesStackWhenReportCallerEnabled(t *testing.T) {
+	l := New()
+	defer l.Close()
+	l.SetReportCaller(true)
+
+	hook := &captureHook{levels: []LogLevel{LogLevelError}}
+	l.AddHook(hook)
+
+	l.Error("boom")
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(hook.entries))
+	}
+	if _, ok := hook.entries[0].Data["stack"]; !ok {
+		t.Error("fireEntry did not attach a stack trace with SetReportCaller on")
+	}
+}
@@ -0,0 +1,72 @@
+package logger
+
+import "strings"
+
+// ColorMode controls when colored [LEVEL] tags are emitted.
+type ColorMode int8
+
+const (
+	// ColorAuto colors output only for sinks backed by a TTY. This is the default.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors every sink, TTY or not.
+	ColorAlways
+	// ColorNever disables coloring entirely.
+	ColorNever
+)
+
+const colorReset = "\x1b[0m"
+
+// GetColor returns the ANSI color escape code associated with l, or an empty
+// string for unrecognized levels.
+func (l LogLevel) GetColor() string {
+	switch l {
+	case LogLevelDebug:
+		return "\x1b[36m" // cyan
+	case LogLevelInfo:
+		return "\x1b[34m" // blue
+	case LogLevelWarning:
+		return "\x1b[33m" // yellow
+	case LogLevelError:
+		return "\x1b[31m" // red
+	case LogLevelFatal:
+		return "\x1b[35m" // magenta
+	default:
+		return ""
+	}
+}
+
+// GetReset returns the ANSI escape code that resets color set by GetColor.
+func (l LogLevel) GetReset() string {
+	return colorReset
+}
+
+// SetColorMode controls whether colored [LEVEL] tags are emitted. The
+// default, ColorAuto, colors a sink's output only when that sink's
+// underlying writer is a TTY, so piping to a file or another process yields
+// plain text.
+func (l *LogExtended) SetColorMode(mode ColorMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.colorMode = mode
+}
+
+func (l *LogExtended) shouldColor(s *sink) bool {
+	switch l.colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		return s.isTTY
+	}
+}
+
+// colorizeTag wraps the "[LEVEL]" tag in line with level's ANSI color code.
+func colorizeTag(line string, level LogLevel) string {
+	color := level.GetColor()
+	if color == "" {
+		return line
+	}
+	tag := "[" + level.String() + "]"
+	return strings.Replace(line, tag, color+tag+level.GetReset(), 1)
+}
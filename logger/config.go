@@ -0,0 +1,45 @@
+package logger
+
+import "time"
+
+// Config tunes the background flusher that New starts for a LogExtended.
+// Zero values fall back to sensible defaults; rotation is disabled unless
+// MaxFileSize is set.
+type Config struct {
+	// BufferSize is the capacity, in entries, of the in-memory ring buffer
+	// that Log/Logf/etc. write to. Once full, the oldest buffered entry is
+	// dropped to make room for the newest. Defaults to 1024.
+	BufferSize int
+
+	// FlushInterval is how often the background flusher drains the ring
+	// buffer even if it hasn't filled up. Defaults to 1 second.
+	FlushInterval time.Duration
+
+	// MaxFileSize is the size, in bytes, at which the file sink configured
+	// via SetOutput is rotated. Zero disables rotation.
+	MaxFileSize int64
+
+	// MaxBackups is the number of rotated files to retain. Older backups
+	// are deleted as new ones are created. Zero keeps a single backup, which
+	// each rotation overwrites.
+	MaxBackups int
+
+	// MaxAgeDays is the maximum age, in days, a rotated backup is kept
+	// regardless of MaxBackups. Zero disables age-based cleanup.
+	MaxAgeDays int
+}
+
+const (
+	defaultBufferSize    = 1024
+	defaultFlushInterval = time.Second
+)
+
+func (c Config) withDefaults() Config {
+	if c.BufferSize <= 0 {
+		c.BufferSize = defaultBufferSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaultFlushInterval
+	}
+	return c
+}
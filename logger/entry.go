@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entry represents a single log event. It accumulates structured attributes
+// via WithFields/WithField/WithError. Firing it through one of the level
+// methods hands hooks and the formatter a copy that also carries the
+// timestamp, level and formatted message; the Entry the method was called on
+// is left unchanged.
+type Entry struct {
+	logger *LogExtended
+
+	// Data holds the structured attributes attached to this entry.
+	Data map[string]interface{}
+
+	// Time is the moment the entry was fired.
+	Time time.Time
+
+	// Level is the log level the entry was fired at.
+	Level LogLevel
+
+	// Message is the formatted log message, without attributes.
+	Message string
+}
+
+func newEntry(l *LogExtended) *Entry {
+	return &Entry{logger: l, Data: make(map[string]interface{})}
+}
+
+// WithFields returns a new Entry with the given attributes merged on top of
+// the receiver's. The receiver is left unmodified so it can be reused across
+// calls.
+func (e *Entry) WithFields(fields map[string]interface{}) *Entry {
+	data := make(map[string]interface{}, len(e.Data)+len(fields))
+	for k, v := range e.Data {
+		data[k] = v
+	}
+	for k, v := range fields {
+		data[k] = v
+	}
+	return &Entry{logger: e.logger, Data: data}
+}
+
+// WithField is a shorthand for WithFields with a single key/value pair.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return e.WithFields(map[string]interface{}{key: value})
+}
+
+// WithError is a shorthand for WithField("error", err).
+func (e *Entry) WithError(err error) *Entry {
+	return e.WithField("error", err)
+}
+
+// clone returns a copy of e with its own Data map, so fireEntry can set
+// Time/Level/Message and add attributes like "caller"/"stack" without
+// mutating an Entry the caller may be holding onto and reusing - WithFields
+// promises exactly that reuse, by returning a new Entry rather than
+// modifying the receiver.
+func (e *Entry) clone() *Entry {
+	data := make(map[string]interface{}, len(e.Data))
+	for k, v := range e.Data {
+		data[k] = v
+	}
+	return &Entry{logger: e.logger, Data: data}
+}
+
+// Log and every convenience method below call the logger's fireEntry
+// directly, rather than through one another, so each is exactly one frame
+// above fireEntry - the same depth as LogExtended's own Log/Debug/etc.
+// SetReportCaller's caller and stack capture skip these frames by function
+// name (see isInternalFunc), not by a fixed count.
+func (e *Entry) Log(level LogLevel, args ...interface{}) {
+	e.logger.fireEntry(e, level, fmt.Sprint(args...))
+}
+
+func (e *Entry) Logf(format string, level LogLevel, args ...interface{}) {
+	e.logger.fireEntry(e, level, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Debug(args ...interface{}) { e.logger.fireEntry(e, LogLevelDebug, fmt.Sprint(args...)) }
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.logger.fireEntry(e, LogLevelDebug, fmt.Sprintf(format, args...))
+}
+func (e *Entry) Info(args ...interface{}) { e.logger.fireEntry(e, LogLevelInfo, fmt.Sprint(args...)) }
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.logger.fireEntry(e, LogLevelInfo, fmt.Sprintf(format, args...))
+}
+func (e *Entry) Warn(args ...interface{}) { e.logger.fireEntry(e, LogLevelWarning, fmt.Sprint(args...)) }
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.logger.fireEntry(e, LogLevelWarning, fmt.Sprintf(format, args...))
+}
+func (e *Entry) Error(args ...interface{}) { e.logger.fireEntry(e, LogLevelError, fmt.Sprint(args...)) }
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.logger.fireEntry(e, LogLevelError, fmt.Sprintf(format, args...))
+}
+func (e *Entry) Fatal(args ...interface{}) { e.logger.fireEntry(e, LogLevelFatal, fmt.Sprint(args...)) }
+func (e *Entry) Fatalf(format string, args ...interface{}) {
+	e.logger.fireEntry(e, LogLevelFatal, fmt.Sprintf(format, args...))
+}
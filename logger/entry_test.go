@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEntryReusedConcurrently(t *testing.T) {
+	l := New()
+	defer l.Close()
+	l.SetReportCaller(true)
+
+	entry := l.WithField("request_id", "abc")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			entry.Info("from goroutine one")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			entry.Warn("from goroutine two")
+		}
+	}()
+
+	wg.Wait()
+
+	if _, ok := entry.Data["caller"]; ok {
+		t.Error("fireEntry mutated the shared Entry's Data map")
+	}
+	if entry.Message != "" {
+		t.Errorf("fireEntry mutated the shared Entry's Message, got %q", entry.Message)
+	}
+}
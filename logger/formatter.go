@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Formatter renders a fully-populated Entry into the bytes written to the
+// logger's output. Implementations must be safe for concurrent use.
+type Formatter interface {
+	Format(entry *Entry) ([]byte, error)
+}
+
+const defaultTimestampFormat = "2006-01-02 15:04:05"
+
+// TextFormatter renders entries in mlog's original human-readable layout:
+// "[timestamp] [LEVEL] message key=value ...". It is the default formatter.
+type TextFormatter struct {
+	// TimestampFormat overrides the default timestamp layout.
+	TimestampFormat string
+}
+
+func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = defaultTimestampFormat
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[%s] [%s] %s", entry.Time.Format(timestampFormat), entry.Level.String(), entry.Message)
+	for _, key := range sortedKeys(entry.Data) {
+		if key == "stack" {
+			continue
+		}
+		fmt.Fprintf(&buf, " %s=%v", key, entry.Data[key])
+	}
+	if stack, ok := entry.Data["stack"]; ok {
+		fmt.Fprintf(&buf, "\n%v", stack)
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONFormatter renders one JSON object per line, with "ts", "level" and
+// "msg" fields alongside the entry's attributes.
+type JSONFormatter struct {
+	// TimestampFormat overrides the default timestamp layout.
+	TimestampFormat string
+}
+
+func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = defaultTimestampFormat
+	}
+
+	data := make(map[string]interface{}, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	data["ts"] = entry.Time.Format(timestampFormat)
+	data["level"] = entry.Level.String()
+	data["msg"] = entry.Message
+
+	return json.Marshal(data)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
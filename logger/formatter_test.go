@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func testEntry() *Entry {
+	return &Entry{
+		Data:    map[string]interface{}{"user": "alice", "count": 3},
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   LogLevelInfo,
+		Message: "hello",
+	}
+}
+
+func TestTextFormatterLayout(t *testing.T) {
+	f := &TextFormatter{}
+	out, err := f.Format(testEntry())
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	want := "[2024-01-02 03:04:05] [INFO] hello count=3 user=alice"
+	if string(out) != want {
+		t.Errorf("Format() = %q, want %q", out, want)
+	}
+}
+
+func TestTextFormatterAppendsStackOnItsOwnLine(t *testing.T) {
+	f := &TextFormatter{}
+	entry := testEntry()
+	entry.Data["stack"] = "\tsome.Func\n\t\tfile.go:1"
+
+	out, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	want := "[2024-01-02 03:04:05] [INFO] hello count=3 user=alice\n\tsome.Func\n\t\tfile.go:1"
+	if string(out) != want {
+		t.Errorf("Format() = %q, want %q", out, want)
+	}
+}
+
+func TestJSONFormatterFields(t *testing.T) {
+	f := &JSONFormatter{}
+	out, err := f.Format(testEntry())
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", out, err)
+	}
+
+	want := map[string]interface{}{
+		"ts":    "2024-01-02 03:04:05",
+		"level": "INFO",
+		"msg":   "hello",
+		"user":  "alice",
+		"count": float64(3),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("field %q = %v, want %v", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package logger
+
+import "fmt"
+
+// Hook lets external code observe fired entries, e.g. to forward errors to
+// Sentry/Slack or mirror warnings to a separate audit trail. A hook only
+// receives entries at the levels returned by Levels.
+type Hook interface {
+	Levels() []LogLevel
+	Fire(entry *Entry) error
+}
+
+// LevelHooks indexes registered hooks by the level they fire on.
+type LevelHooks map[LogLevel][]Hook
+
+// Add registers hook for each of the levels it reports via Levels.
+func (hooks LevelHooks) Add(hook Hook) {
+	for _, level := range hook.Levels() {
+		hooks[level] = append(hooks[level], hook)
+	}
+}
+
+// clone returns a copy of hooks, including copies of each level's hook
+// slice, so the original can keep being read (e.g. by a fireEntry call
+// already in flight) while the copy is mutated.
+func (hooks LevelHooks) clone() LevelHooks {
+	out := make(LevelHooks, len(hooks))
+	for level, hs := range hooks {
+		out[level] = append([]Hook(nil), hs...)
+	}
+	return out
+}
+
+// Fire runs every hook registered for level, stopping at the first error.
+func (hooks LevelHooks) Fire(level LogLevel, entry *Entry) error {
+	for _, hook := range hooks[level] {
+		if err := hook.Fire(entry); err != nil {
+			return fmt.Errorf("logger: hook failed to fire: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type countingHook struct {
+	levels []LogLevel
+	fired  int32
+}
+
+func (h *countingHook) Levels() []LogLevel { return h.levels }
+
+func (h *countingHook) Fire(entry *Entry) error {
+	atomic.AddInt32(&h.fired, 1)
+	return nil
+}
+
+func TestAddHookConcurrentWithFire(t *testing.T) {
+	l := New()
+	defer l.Close()
+
+	hook := &countingHook{levels: []LogLevel{LogLevelInfo}}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.AddHook(hook)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.Info("hello")
+		}
+	}()
+
+	wg.Wait()
+}
@@ -1,12 +1,12 @@
 package logger
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -40,9 +40,20 @@ func (l LogLevel) String() string {
 type Logger interface {
 	SetLogLevel(level LogLevel)
 	SetOutput(filepath string) error
+	SetFormatter(formatter Formatter)
+	SetColorMode(mode ColorMode)
+	SetReportCaller(enabled bool)
+	AddSink(writer io.Writer, level LogLevel)
+	AddHook(hook Hook)
+	V(level int32) Verbose
+	InitFlags(flagset *flag.FlagSet)
 	Flush() error
 	Close() error
 
+	WithFields(fields map[string]interface{}) *Entry
+	WithField(key string, value interface{}) *Entry
+	WithError(err error) *Entry
+
 	Log(level LogLevel, args ...interface{})
 	Logf(format string, level LogLevel, args ...interface{})
 
@@ -63,19 +74,107 @@ type Logger interface {
 }
 
 type LogExtended struct {
-	bufferWriter *bufio.Writer
-	logFile      *os.File
-	logLevel     LogLevel
-	logger       *log.Logger
-	mu           sync.RWMutex
+	logLevel  LogLevel
+	formatter Formatter
+	stdout    *sink
+	fileSink  *sink
+	logFile   *os.File
+	sinks     []*sink
+	hooks     LevelHooks
+	colorMode    ColorMode
+	reportCaller bool
+	verbosity    int32
+	vmodule      *moduleSpec
+	pcCache      atomic.Pointer[sync.Map]
+
+	cfg          Config
+	ring         *ringBuffer
+	wake         chan struct{}
+	closeCh      chan struct{}
+	doneCh       chan struct{}
+	closeOnce    sync.Once
+	compressDone chan struct{}
+
+	mu sync.RWMutex
+}
+
+// New creates a LogExtended and starts its background flusher goroutine. An
+// optional Config tunes the ring buffer size, flush cadence and file
+// rotation; omitting it uses the defaults described on Config.
+func New(cfg ...Config) *LogExtended {
+	var c Config
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	c = c.withDefaults()
+
+	l := &LogExtended{
+		logLevel:  LogLevelInfo,
+		formatter: &TextFormatter{},
+		stdout:    newSink(os.Stdout, LogLevelDebug),
+		hooks:     make(LevelHooks),
+		cfg:       c,
+		ring:      newRingBuffer(c.BufferSize),
+		wake:      make(chan struct{}, 1),
+		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	l.pcCache.Store(&sync.Map{})
+	go l.flusherLoop()
+	return l
 }
 
-func New() *LogExtended {
-	bufferWriter := bufio.NewWriter(os.Stdout)
-	return &LogExtended{
-		logLevel:     LogLevelInfo,
-		bufferWriter: bufferWriter,
-		logger:       log.New(bufferWriter, "", 0),
+// flusherLoop drains the ring buffer to the sinks on a timer, or immediately
+// when the ring buffer signals it has crossed its high-watermark, so callers
+// of Log/Logf never block on sink I/O.
+func (l *LogExtended) flusherLoop() {
+	defer close(l.doneCh)
+
+	ticker := time.NewTicker(l.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.drainAndWrite()
+		case <-l.wake:
+			l.drainAndWrite()
+		case <-l.closeCh:
+			l.drainAndWrite()
+			return
+		}
+	}
+}
+
+// drainAndWrite writes every currently buffered entry to the sinks that
+// accept its level, flushes the file sink, and rotates it if it has grown
+// past Config.MaxFileSize.
+func (l *LogExtended) drainAndWrite() {
+	entries := l.ring.drain()
+	if len(entries) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sinks := l.allSinks()
+	for _, e := range entries {
+		for _, s := range sinks {
+			if e.level < s.level {
+				continue
+			}
+			out := e.line
+			if l.shouldColor(s) {
+				out = colorizeTag(out, e.level)
+			}
+			s.writeLine(out)
+		}
+	}
+
+	if l.fileSink != nil {
+		l.fileSink.bw.Flush()
+		l.maybeRotate()
 	}
 }
 
@@ -85,12 +184,61 @@ func (l *LogExtended) SetLogLevel(level LogLevel) {
 	l.logLevel = level
 }
 
+// SetFormatter sets the Formatter used to render entries before they are
+// written. The default is a *TextFormatter, which preserves mlog's original
+// human-readable layout.
+func (l *LogExtended) SetFormatter(formatter Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = formatter
+}
+
+// AddSink registers an additional writer that receives every entry at or
+// above level, independently of the stdout/file output configured via
+// SetOutput. Use it to mirror logs to a syslog, HTTP or rotating-file sink.
+func (l *LogExtended) AddSink(writer io.Writer, level LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, newSink(writer, level))
+}
+
+// AddHook registers a Hook to be fired for every entry logged at one of the
+// levels it reports via Levels(). It replaces l.hooks with a copy-on-write
+// clone rather than mutating the published map in place, so fireEntry can
+// read l.hooks without holding l.mu for the duration of Fire.
+func (l *LogExtended) AddHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	hooks := l.hooks.clone()
+	hooks.Add(hook)
+	l.hooks = hooks
+}
+
+// WithFields returns an Entry carrying the given attributes, which are
+// included in any subsequent Log/Logf/Debug/... call made on it.
+func (l *LogExtended) WithFields(fields map[string]interface{}) *Entry {
+	return newEntry(l).WithFields(fields)
+}
+
+// WithField is a shorthand for WithFields with a single key/value pair.
+func (l *LogExtended) WithField(key string, value interface{}) *Entry {
+	return newEntry(l).WithField(key, value)
+}
+
+// WithError is a shorthand for WithField("error", err).
+func (l *LogExtended) WithError(err error) *Entry {
+	return newEntry(l).WithError(err)
+}
+
+// SetOutput points the file sink at filepath, flushing and closing whatever
+// file was previously open. Output continues to be written to stdout as
+// well; register additional destinations with AddSink.
 func (l *LogExtended) SetOutput(filepath string) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.bufferWriter != nil {
-		l.bufferWriter.Flush()
+	if l.fileSink != nil {
+		l.fileSink.bw.Flush()
 	}
 	if l.logFile != nil {
 		l.logFile.Close()
@@ -101,22 +249,34 @@ func (l *LogExtended) SetOutput(filepath string) error {
 		return err
 	}
 
-	multiWriter := io.MultiWriter(file, os.Stdout)
-	bufferWriter := bufio.NewWriter(multiWriter)
-
-	l.logger = log.New(bufferWriter, "", 0)
-	l.bufferWriter = bufferWriter
 	l.logFile = file
+	l.fileSink = newSink(file, LogLevelDebug)
 
 	return nil
 }
 
+// allSinks returns every sink currently receiving output: stdout, the file
+// sink configured via SetOutput (if any), and any sinks added via AddSink.
+func (l *LogExtended) allSinks() []*sink {
+	sinks := make([]*sink, 0, len(l.sinks)+2)
+	if l.stdout != nil {
+		sinks = append(sinks, l.stdout)
+	}
+	if l.fileSink != nil {
+		sinks = append(sinks, l.fileSink)
+	}
+	return append(sinks, l.sinks...)
+}
+
+// Flush drains the ring buffer and forces all buffered logs to be written to
+// the output.
 func (l *LogExtended) Flush() error {
+	l.drainAndWrite()
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if l.bufferWriter != nil {
-		err := l.bufferWriter.Flush()
-		if err != nil {
+	for _, s := range l.allSinks() {
+		if err := s.bw.Flush(); err != nil {
 			return err
 		}
 	}
@@ -126,13 +286,18 @@ func (l *LogExtended) Flush() error {
 	return nil
 }
 
+// Close stops the background flusher, draining the ring buffer before it
+// exits, then flushes and closes the log file if one was opened.
 func (l *LogExtended) Close() error {
+	l.closeOnce.Do(func() { close(l.closeCh) })
+	<-l.doneCh
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	var err error
-	if l.bufferWriter != nil {
-		if e := l.bufferWriter.Flush(); e != nil {
+	for _, s := range l.allSinks() {
+		if e := s.bw.Flush(); e != nil {
 			err = e
 		}
 	}
@@ -144,73 +309,107 @@ func (l *LogExtended) Close() error {
 	return err
 }
 
-func (l *LogExtended) formatMessage(level LogLevel, args ...interface{}) string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	return fmt.Sprintf("[%s] [%s] %v", timestamp, level.String(), fmt.Sprint(args...))
-}
-
-func (l *LogExtended) formatMessagef(format string, level LogLevel, args ...interface{}) string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	return fmt.Sprintf("[%s] [%s] %s", timestamp, level.String(), fmt.Sprintf(format, args...))
-}
-
-func (l *LogExtended) Log(level LogLevel, args ...interface{}) {
+// fireEntry formats entry at level with message and pushes it onto the ring
+// buffer for the background flusher to write out, so callers never block on
+// sink I/O. It applies the fatal-exit behavior shared by Log/Logf and the
+// Entry level methods, forcing a synchronous drain first so the fatal entry
+// is not lost.
+//
+// It fires a clone of entry rather than entry itself: WithFields documents
+// that the Entry it returns can be reused across calls, so fireEntry must
+// not mutate the caller's Data map or set Time/Level/Message on it - doing
+// so raced with a second goroutine firing the same Entry concurrently.
+func (l *LogExtended) fireEntry(entry *Entry, level LogLevel, message string) {
 	l.mu.RLock()
-
 	if level < l.logLevel {
 		l.mu.RUnlock()
 		return
 	}
-
+	formatter := l.formatter
+	hooks := l.hooks
+	reportCaller := l.reportCaller
 	l.mu.RUnlock()
 
-	l.mu.Lock()
-
-	l.logger.Println(l.formatMessage(level, args...))
+	entry = entry.clone()
+	entry.Time = time.Now()
+	entry.Level = level
+	entry.Message = message
 
-	if level == LogLevelFatal {
-		l.Flush()
-		if l.logFile != nil {
-			l.logFile.Close()
+	if reportCaller {
+		if caller, ok := captureCaller(); ok {
+			entry.Data["caller"] = caller
+		}
+		if level == LogLevelError || level == LogLevelFatal {
+			entry.Data["stack"] = resolveStack(entry.Data["error"])
 		}
-		os.Exit(1)
 	}
 
-	l.mu.Unlock()
-}
-
-func (l *LogExtended) Logf(format string, level LogLevel, args ...interface{}) {
-	l.mu.RLock()
-
-	if level < l.logLevel {
-		l.mu.RUnlock()
-		return
+	if err := hooks.Fire(level, entry); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 	}
 
-	l.mu.RUnlock()
-
-	l.mu.Lock()
+	line, err := formatter.Format(entry)
+	if err != nil {
+		line = []byte(fmt.Sprintf("[%s] [%s] %s (formatter error: %v)", entry.Time.Format(defaultTimestampFormat), level.String(), message, err))
+	}
 
-	l.logger.Println(l.formatMessagef(format, level, args...))
+	if l.ring.push(bufferedEntry{line: string(line), level: level}) {
+		select {
+		case l.wake <- struct{}{}:
+		default:
+		}
+	}
 
 	if level == LogLevelFatal {
 		l.Flush()
+		l.mu.Lock()
 		if l.logFile != nil {
 			l.logFile.Close()
 		}
+		l.mu.Unlock()
 		os.Exit(1)
 	}
+}
+
+// Log and every convenience method below call fireEntry directly, rather
+// than through one another, so each is exactly one frame above fireEntry.
+// SetReportCaller's caller and stack capture skip these frames by function
+// name (see isInternalFunc), not by a fixed count.
+func (l *LogExtended) Log(level LogLevel, args ...interface{}) {
+	l.fireEntry(newEntry(l), level, fmt.Sprint(args...))
+}
 
-	l.mu.Unlock()
+func (l *LogExtended) Logf(format string, level LogLevel, args ...interface{}) {
+	l.fireEntry(newEntry(l), level, fmt.Sprintf(format, args...))
 }
 
-func (l *LogExtended) Debug(args ...interface{})  { l.Log(LogLevelDebug, args...) }
-func (l *LogExtended) Debugf(format string, args ...interface{}) { l.Logf(format, LogLevelDebug, args...) }
-func (l *LogExtended) Info(args ...interface{})   { l.Log(LogLevelInfo, args...) }
-func (l *LogExtended) Infof(format string, args ...interface{})  { l.Logf(format, LogLevelInfo, args...) }
-func (l *LogExtended) Warn(args ...interface{})   { l.Log(LogLevelWarning, args...) }
-func (l *LogExtended) Warnf(format string, args ...interface{})  { l.Logf(format, LogLevelWarning, args...) }
-func (l *LogExtended) Error(args ...interface{})  { l.Log(LogLevelError, args...) }
-func (l *LogExtended) Errorf(format string, args ...interface{}) { l.Logf(format, LogLevelError, args...) }
-func (l *LogExtended) Fatal(args ...interface{})  { l.Log(LogLevelFatal, args...) }
-func (l *LogExtended) Fatalf(format string, args ...interface{}) { l.Logf(format, LogLevelFatal, args...) }
+func (l *LogExtended) Debug(args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelDebug, fmt.Sprint(args...))
+}
+func (l *LogExtended) Debugf(format string, args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelDebug, fmt.Sprintf(format, args...))
+}
+func (l *LogExtended) Info(args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelInfo, fmt.Sprint(args...))
+}
+func (l *LogExtended) Infof(format string, args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelInfo, fmt.Sprintf(format, args...))
+}
+func (l *LogExtended) Warn(args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelWarning, fmt.Sprint(args...))
+}
+func (l *LogExtended) Warnf(format string, args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelWarning, fmt.Sprintf(format, args...))
+}
+func (l *LogExtended) Error(args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelError, fmt.Sprint(args...))
+}
+func (l *LogExtended) Errorf(format string, args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelError, fmt.Sprintf(format, args...))
+}
+func (l *LogExtended) Fatal(args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelFatal, fmt.Sprint(args...))
+}
+func (l *LogExtended) Fatalf(format string, args ...interface{}) {
+	l.fireEntry(newEntry(l), LogLevelFatal, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,65 @@
+package logger
+
+import "sync"
+
+// bufferedEntry is a formatted log line waiting to be written by the
+// background flusher.
+type bufferedEntry struct {
+	line  string
+	level LogLevel
+}
+
+// ringBuffer is a fixed-capacity circular buffer of bufferedEntry. Once
+// full, pushing a new entry overwrites the oldest one rather than blocking
+// or growing, so callers never wait on the flusher.
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []bufferedEntry
+	head    int
+	count   int
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	if size <= 0 {
+		size = 1
+	}
+	return &ringBuffer{entries: make([]bufferedEntry, size)}
+}
+
+// push adds e to the buffer, overwriting the oldest entry if full. head
+// always points at the next write position, whether or not the buffer has
+// wrapped, which is what lets drain locate the oldest entry from head and
+// count alone. It reports whether the buffer has crossed its high-watermark
+// (75% full), a hint for the flusher to wake immediately instead of waiting
+// for its timer.
+func (r *ringBuffer) push(e bufferedEntry) (highWater bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := len(r.entries)
+	r.entries[r.head] = e
+	r.head = (r.head + 1) % size
+	if r.count < size {
+		r.count++
+	}
+	return r.count*4 >= size*3
+}
+
+// drain removes and returns every buffered entry, oldest first.
+func (r *ringBuffer) drain() []bufferedEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.count == 0 {
+		return nil
+	}
+	size := len(r.entries)
+	start := (r.head - r.count + size) % size
+	out := make([]bufferedEntry, r.count)
+	for i := 0; i < r.count; i++ {
+		out[i] = r.entries[(start+i)%size]
+	}
+	r.head = 0
+	r.count = 0
+	return out
+}
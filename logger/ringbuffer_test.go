@@ -0,0 +1,50 @@
+package logger
+
+import "testing"
+
+func TestRingBufferDrainOrderBelowCapacity(t *testing.T) {
+	r := newRingBuffer(8)
+
+	for i := 0; i < 5; i++ {
+		r.push(bufferedEntry{line: string(rune('a' + i)), level: LogLevelInfo})
+	}
+
+	out := r.drain()
+	if len(out) != 5 {
+		t.Fatalf("drain() returned %d entries, want 5", len(out))
+	}
+	for i, e := range out {
+		want := string(rune('a' + i))
+		if e.line != want {
+			t.Errorf("entry %d = %q, want %q", i, e.line, want)
+		}
+	}
+}
+
+func TestRingBufferDrainOrderAfterWrap(t *testing.T) {
+	r := newRingBuffer(4)
+
+	for i := 0; i < 6; i++ {
+		r.push(bufferedEntry{line: string(rune('a' + i)), level: LogLevelInfo})
+	}
+
+	// Capacity 4, 6 pushes: the oldest two ('a', 'b') were overwritten, so
+	// drain should return c, d, e, f in order.
+	out := r.drain()
+	want := []string{"c", "d", "e", "f"}
+	if len(out) != len(want) {
+		t.Fatalf("drain() returned %d entries, want %d", len(out), len(want))
+	}
+	for i, e := range out {
+		if e.line != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, e.line, want[i])
+		}
+	}
+}
+
+func TestRingBufferDrainEmpty(t *testing.T) {
+	r := newRingBuffer(4)
+	if out := r.drain(); out != nil {
+		t.Fatalf("drain() on empty buffer = %v, want nil", out)
+	}
+}
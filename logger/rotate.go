@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maybeRotate rotates the file sink if MaxFileSize is configured and
+// exceeded. Callers must hold l.mu.
+func (l *LogExtended) maybeRotate() {
+	if l.cfg.MaxFileSize <= 0 || l.logFile == nil {
+		return
+	}
+	info, err := l.logFile.Stat()
+	if err != nil || info.Size() < l.cfg.MaxFileSize {
+		return
+	}
+	l.rotate()
+}
+
+// rotate closes the current log file, shifts existing numbered backups
+// (app.log.1.gz -> app.log.2.gz, ...) dropping anything beyond MaxBackups,
+// renames app.log to app.log.1, and reopens app.log for further writes. The
+// newly rotated file is gzip-compressed by a background goroutine so the
+// flusher never blocks on it. Callers must hold l.mu.
+func (l *LogExtended) rotate() {
+	basePath := l.logFile.Name()
+	l.logFile.Close()
+
+	// The previous rotation's compressBackup goroutine reads from and then
+	// removes basePath+".1", and writes basePath+".1.gz"; wait for it to
+	// finish before shifting backups or reusing that path, or the shift could
+	// miss a .1.gz still being written and this rename could land
+	// mid-compression, corrupting or losing the backup.
+	if l.compressDone != nil {
+		<-l.compressDone
+	}
+
+	if l.cfg.MaxBackups > 0 {
+		if err := os.Remove(fmt.Sprintf("%s.%d.gz", basePath, l.cfg.MaxBackups)); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "logger: rotate: removing oldest backup: %v\n", err)
+		}
+		for i := l.cfg.MaxBackups - 1; i >= 1; i-- {
+			src, dst := fmt.Sprintf("%s.%d.gz", basePath, i), fmt.Sprintf("%s.%d.gz", basePath, i+1)
+			if err := os.Rename(src, dst); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "logger: rotate: shifting backup %s: %v\n", src, err)
+			}
+		}
+	}
+
+	rotated := basePath + ".1"
+	if err := os.Rename(basePath, rotated); err != nil {
+		fmt.Fprintf(os.Stderr, "logger: rotate: renaming %s to %s: %v\n", basePath, rotated, err)
+	}
+
+	file, err := os.OpenFile(basePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		l.logFile = nil
+		l.fileSink = nil
+		return
+	}
+	l.logFile = file
+	l.fileSink = newSink(file, LogLevelDebug)
+
+	done := make(chan struct{})
+	l.compressDone = done
+	maxAgeDays := l.cfg.MaxAgeDays
+	go func() {
+		defer close(done)
+		compressBackup(rotated, basePath, maxAgeDays)
+	}()
+}
+
+// compressBackup gzip-compresses rotatedPath into rotatedPath+".gz",
+// removing the uncompressed copy, then sweeps basePath's backups for any
+// older than maxAgeDays.
+func compressBackup(rotatedPath, basePath string, maxAgeDays int) {
+	if err := gzipFile(rotatedPath); err != nil {
+		fmt.Fprintf(os.Stderr, "logger: rotate: compressing %s: %v\n", rotatedPath, err)
+	} else if err := os.Remove(rotatedPath); err != nil {
+		fmt.Fprintf(os.Stderr, "logger: rotate: removing %s: %v\n", rotatedPath, err)
+	}
+
+	if maxAgeDays <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(basePath + ".*.gz")
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().AddDate(0, 0, -maxAgeDays)
+	for _, m := range matches {
+		if fi, err := os.Stat(m); err == nil && fi.ModTime().Before(cutoff) {
+			if err := os.Remove(m); err != nil {
+				fmt.Fprintf(os.Stderr, "logger: rotate: removing aged backup %s: %v\n", m, err)
+			}
+		}
+	}
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return err
+	}
+	return gw.Close()
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotateSerializesAgainstCompression(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	l := New(Config{MaxBackups: 2})
+	defer l.Close()
+
+	if err := l.SetOutput(path); err != nil {
+		t.Fatalf("SetOutput: %v", err)
+	}
+
+	l.mu.Lock()
+	l.rotate()
+	l.mu.Unlock()
+
+	l.mu.Lock()
+	l.rotate()
+	l.mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		_, err1 := os.Stat(path + ".1.gz")
+		_, err2 := os.Stat(path + ".2.gz")
+		if err1 == nil && err2 == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("expected %s.1.gz and %s.2.gz to exist after two rapid rotations", path, path)
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// sink pairs a buffered writer with the minimum level it should receive.
+type sink struct {
+	bw    *bufio.Writer
+	level LogLevel
+	isTTY bool
+}
+
+func newSink(w io.Writer, level LogLevel) *sink {
+	return &sink{bw: bufio.NewWriter(w), level: level, isTTY: isTerminal(w)}
+}
+
+// isTerminal reports whether w is a character device such as an interactive
+// terminal, as opposed to a regular file or pipe.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (s *sink) writeLine(line string) error {
+	if _, err := s.bw.WriteString(line); err != nil {
+		return err
+	}
+	return s.bw.WriteByte('\n')
+}
@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Verbose is returned by V and gates a block of verbose logging behind a
+// boolean check, the way glog/klog's V-levels do: Info/Infof/Infoln are
+// no-ops unless the call site's verbosity was enabled.
+type Verbose struct {
+	enabled bool
+	logger  *LogExtended
+}
+
+func (v Verbose) Info(args ...interface{}) {
+	if v.enabled {
+		v.logger.Info(args...)
+	}
+}
+
+func (v Verbose) Infof(format string, args ...interface{}) {
+	if v.enabled {
+		v.logger.Infof(format, args...)
+	}
+}
+
+func (v Verbose) Infoln(args ...interface{}) {
+	if v.enabled {
+		v.logger.Info(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	}
+}
+
+// moduleSpec holds the parsed patterns from a -vmodule flag.
+type moduleSpec struct {
+	filters []modulePattern
+}
+
+type modulePattern struct {
+	pattern string
+	level   int32
+}
+
+func parseModuleSpec(value string) (*moduleSpec, error) {
+	spec := &moduleSpec{}
+	if value == "" {
+		return spec, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		eq := strings.LastIndex(part, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("logger: malformed -vmodule entry %q, expected pattern=N", part)
+		}
+		level, err := strconv.ParseInt(part[eq+1:], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("logger: malformed -vmodule entry %q: %w", part, err)
+		}
+		spec.filters = append(spec.filters, modulePattern{pattern: part[:eq], level: int32(level)})
+	}
+	return spec, nil
+}
+
+// findLevel returns the verbosity level configured for basename by the first
+// matching pattern, if any.
+func (m *moduleSpec) findLevel(basename string) (int32, bool) {
+	for _, f := range m.filters {
+		if matched, _ := filepath.Match(f.pattern, basename); matched {
+			return f.level, true
+		}
+	}
+	return 0, false
+}
+
+// V reports whether verbose logging at level is enabled for the caller's
+// source file, given the global -v level and any -vmodule overrides, and
+// returns a Verbose that logs through l when it is.
+//
+// A disabled V-log costs an atomic load of the global level and a read-locked
+// load of the -vmodule spec; when a spec is set, it also costs a
+// runtime.Callers call and a sync.Map lookup keyed by the two innermost
+// caller program counters. runtime.CallersFrames, which resolves the
+// caller's file name, only runs the first time that key is seen. A call made
+// through mlog.V is matched against the file of mlog.V's caller.
+func (l *LogExtended) V(level int32) Verbose {
+	if atomic.LoadInt32(&l.verbosity) >= level {
+		return Verbose{enabled: true, logger: l}
+	}
+
+	l.mu.RLock()
+	vmodule := l.vmodule
+	l.mu.RUnlock()
+	if vmodule == nil || len(vmodule.filters) == 0 {
+		return Verbose{enabled: false, logger: l}
+	}
+
+	var pcs [2]uintptr
+	n := runtime.Callers(2, pcs[:])
+	if n == 0 {
+		return Verbose{enabled: false, logger: l}
+	}
+
+	pcCache := l.pcCache.Load()
+	if cached, ok := pcCache.Load(pcs); ok {
+		return Verbose{enabled: level <= cached.(int32), logger: l}
+	}
+
+	threshold := int32(-1)
+	frames := runtime.CallersFrames(pcs[:n])
+	frame, more := frames.Next()
+	if frame.Function == "github.com/ariocp/mlog/mlog.V" && more {
+		frame, _ = frames.Next()
+	}
+	if frame.File != "" {
+		if lvl, ok := vmodule.findLevel(filepath.Base(frame.File)); ok {
+			threshold = lvl
+		}
+	}
+	pcCache.Store(pcs, threshold)
+
+	return Verbose{enabled: level <= threshold, logger: l}
+}
+
+type vFlagValue struct{ logger *LogExtended }
+
+func (f *vFlagValue) String() string {
+	if f.logger == nil {
+		return "0"
+	}
+	return strconv.FormatInt(int64(atomic.LoadInt32(&f.logger.verbosity)), 10)
+}
+
+func (f *vFlagValue) Set(s string) error {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt32(&f.logger.verbosity, int32(n))
+	return nil
+}
+
+type vmoduleFlagValue struct{ logger *LogExtended }
+
+func (f *vmoduleFlagValue) String() string {
+	return ""
+}
+
+func (f *vmoduleFlagValue) Set(s string) error {
+	spec, err := parseModuleSpec(s)
+	if err != nil {
+		return err
+	}
+	f.logger.mu.Lock()
+	f.logger.vmodule = spec
+	f.logger.mu.Unlock()
+	// pcCache is an atomic.Pointer[sync.Map], so it can be reset to a fresh
+	// map without taking l.mu - V reads it the same way.
+	f.logger.pcCache.Store(&sync.Map{})
+	return nil
+}
+
+// InitFlags registers the -v and -vmodule flags on flagset, binding them to
+// l's verbosity state. A nil flagset registers them on flag.CommandLine.
+func (l *LogExtended) InitFlags(flagset *flag.FlagSet) {
+	if flagset == nil {
+		flagset = flag.CommandLine
+	}
+	flagset.Var(&vFlagValue{logger: l}, "v", "log level for V logs")
+	flagset.Var(&vmoduleFlagValue{logger: l}, "vmodule", "comma-separated list of pattern=N settings for file-filtered logging")
+}
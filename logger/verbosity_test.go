@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParseModuleSpecMatchesBasename(t *testing.T) {
+	spec, err := parseModuleSpec("foo*=2,bar.go=3")
+	if err != nil {
+		t.Fatalf("parseModuleSpec: %v", err)
+	}
+
+	level, ok := spec.findLevel("foo_test.go")
+	if !ok || level != 2 {
+		t.Errorf("findLevel(foo_test.go) = %d, %v, want 2, true", level, ok)
+	}
+
+	level, ok = spec.findLevel("bar.go")
+	if !ok || level != 3 {
+		t.Errorf("findLevel(bar.go) = %d, %v, want 3, true", level, ok)
+	}
+
+	if _, ok := spec.findLevel("baz.go"); ok {
+		t.Errorf("findLevel(baz.go) matched, want no match")
+	}
+}
+
+func TestParseModuleSpecMalformed(t *testing.T) {
+	if _, err := parseModuleSpec("noequalsign"); err == nil {
+		t.Error("expected an error for a pattern without '=N'")
+	}
+}
+
+func TestVConcurrentWithVmoduleReset(t *testing.T) {
+	l := New()
+	defer l.Close()
+	l.InitFlags(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			l.V(1).Info("verbose")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if err := (&vmoduleFlagValue{logger: l}).Set("verbosity_test.go=1"); err != nil {
+				t.Errorf("Set: %v", err)
+			}
+		}
+	}()
+
+	wg.Wait()
+}
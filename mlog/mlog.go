@@ -1,6 +1,11 @@
 package mlog
 
-import "github.com/ariocp/mlog/logger"
+import (
+	"flag"
+	"io"
+
+	"github.com/ariocp/mlog/logger"
+)
 
 var mlog = logger.New()
 
@@ -12,6 +17,50 @@ func SetLogLevel(level logger.LogLevel) { mlog.SetLogLevel(level) }
 // If a file path is specified, it opens the file for appending logs and writes to both the file and stdout
 func SetOutput(filepath string) error { return mlog.SetOutput(filepath) }
 
+// SetFormatter sets the Formatter used to render log entries before they are
+// written. The default is a *logger.TextFormatter, which preserves mlog's
+// original human-readable layout; use a *logger.JSONFormatter to emit one
+// JSON object per line instead
+func SetFormatter(formatter logger.Formatter) { mlog.SetFormatter(formatter) }
+
+// WithFields returns a logger.Entry carrying the given attributes, which are
+// included in any subsequent Log/Logf/Debug/... call made on it
+func WithFields(fields map[string]interface{}) *logger.Entry { return mlog.WithFields(fields) }
+
+// WithField is a shorthand for WithFields with a single key/value pair
+func WithField(key string, value interface{}) *logger.Entry { return mlog.WithField(key, value) }
+
+// WithError is a shorthand for WithField("error", err)
+func WithError(err error) *logger.Entry { return mlog.WithError(err) }
+
+// SetColorMode controls whether colored [LEVEL] tags are emitted. The
+// default, logger.ColorAuto, colors a sink's output only when that sink's
+// underlying writer is a TTY
+func SetColorMode(mode logger.ColorMode) { mlog.SetColorMode(mode) }
+
+// SetReportCaller controls whether fired entries are annotated with the
+// file:line of their call site. It is off by default since runtime.Caller is
+// not free
+func SetReportCaller(enabled bool) { mlog.SetReportCaller(enabled) }
+
+// AddSink registers an additional writer that receives every log entry at or
+// above level, independently of the stdout/file output configured via
+// SetOutput
+func AddSink(writer io.Writer, level logger.LogLevel) { mlog.AddSink(writer, level) }
+
+// AddHook registers a Hook to be fired for every entry logged at one of the
+// levels it reports via Levels()
+func AddHook(hook logger.Hook) { mlog.AddHook(hook) }
+
+// V reports whether verbose logging at level is enabled, per the -v flag and
+// any -vmodule override for the caller's source file, and returns a Verbose
+// that logs through mlog's Info when it is
+func V(level int32) logger.Verbose { return mlog.V(level) }
+
+// InitFlags registers the -v and -vmodule flags on flagset, binding them to
+// mlog's verbosity state. A nil flagset registers them on flag.CommandLine
+func InitFlags(flagset *flag.FlagSet) { mlog.InitFlags(flagset) }
+
 // Flush forces all buffered logs to be written to the output
 // This ensures that all log messages are actually written to the output, including any buffered data
 func Flush() error { return mlog.Flush() }
@@ -0,0 +1,50 @@
+package mlog
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ariocp/mlog/logger"
+)
+
+type captureHook struct {
+	mu      sync.Mutex
+	entries []*logger.Entry
+}
+
+func (h *captureHook) Levels() []logger.LogLevel { return []logger.LogLevel{logger.LogLevelInfo} }
+
+func (h *captureHook) Fire(entry *logger.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+// TestCaptureCallerThroughWrapper exercises the extra frame the mlog.*
+// package-level wrappers add over calling *logger.LogExtended directly, to
+// make sure the caller attribute still resolves to this file and not to
+// mlog.go or logger.go.
+func TestCaptureCallerThroughWrapper(t *testing.T) {
+	SetReportCaller(true)
+	defer SetReportCaller(false)
+
+	hook := &captureHook{}
+	AddHook(hook)
+
+	Info("hi")
+	wantLine := 36 // the Info("hi") call above
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(hook.entries))
+	}
+
+	caller, _ := hook.entries[0].Data["caller"].(string)
+	want := fmt.Sprintf("mlog_test.go:%d", wantLine)
+	if caller != want {
+		t.Errorf("caller = %q, want %q", caller, want)
+	}
+}